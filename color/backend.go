@@ -0,0 +1,49 @@
+package color
+
+// LEDBackend translates a color-space-agnostic ColorValue into the concrete
+// map shape a given LED component's DoCommand expects. Different LED
+// drivers accept different representations (raw RGB triples, HSV, or CIE
+// xy) so a backend is chosen per deployment rather than assumed.
+type LEDBackend interface {
+	// ColorCommand returns the entry for this color in the "colors" list of
+	// an animation step.
+	ColorCommand(c ColorValue) any
+}
+
+// RGBBackend emits the legacy []any{r, g, b} triple.
+type RGBBackend struct{}
+
+func (RGBBackend) ColorCommand(c ColorValue) any {
+	r, g, b := c.ToRGB()
+	return []any{r, g, b}
+}
+
+// HSVBackend emits a {"hue", "saturation", "value"} map.
+type HSVBackend struct{}
+
+func (HSVBackend) ColorCommand(c ColorValue) any {
+	h, s, v := c.ToHSV()
+	return map[string]any{"hue": h, "saturation": s, "value": v}
+}
+
+// XYBackend emits a {"x", "y"} map, as expected by LED strips that take CIE
+// xy chromaticity (brightness is controlled separately).
+type XYBackend struct{}
+
+func (XYBackend) ColorCommand(c ColorValue) any {
+	x, y, _ := c.ToXY()
+	return map[string]any{"x": x, "y": y}
+}
+
+// BackendForColorSpace resolves a config's color-space string to an
+// LEDBackend, defaulting to RGB for backward compatibility.
+func BackendForColorSpace(colorSpace string) LEDBackend {
+	switch colorSpace {
+	case "hsv":
+		return HSVBackend{}
+	case "xy":
+		return XYBackend{}
+	default:
+		return RGBBackend{}
+	}
+}
@@ -0,0 +1,313 @@
+// Package color provides a device-agnostic color representation that can be
+// authored in RGB, HSV, or CIE xyY and converted between spaces so callers
+// can target whichever representation their LED hardware expects.
+package color
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ColorKind identifies which fields of a ColorValue are authoritative.
+type ColorKind string
+
+const (
+	KindRGB ColorKind = "rgb"
+	KindHSV ColorKind = "hsv"
+	KindXY  ColorKind = "xy"
+)
+
+// ColorValue holds a color tagged with the space it was authored in, plus
+// room to cache conversions to the other supported spaces.
+type ColorValue struct {
+	Kind ColorKind
+
+	R, G, B uint8
+
+	H, S, V float64
+
+	X, Y, Y_ float64
+}
+
+// RGB builds a ColorValue authored in sRGB.
+func RGB(r, g, b uint8) ColorValue {
+	return ColorValue{Kind: KindRGB, R: r, G: g, B: b}
+}
+
+// HSV builds a ColorValue authored in HSV (h in degrees [0,360), s and v in [0,1]).
+func HSV(h, s, v float64) ColorValue {
+	return ColorValue{Kind: KindHSV, H: h, S: s, V: v}
+}
+
+// XY builds a ColorValue authored in CIE xyY chromaticity, with y_ as luminance.
+func XY(x, y, yLum float64) ColorValue {
+	return ColorValue{Kind: KindXY, X: x, Y: y, Y_: yLum}
+}
+
+const gammaThreshold = 0.04045
+
+func srgbToLinear(c float64) float64 {
+	if c <= gammaThreshold {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(c float64) float64 {
+	if c <= 0.0031308 {
+		return c * 12.92
+	}
+	return 1.055*math.Pow(c, 1/2.4) - 0.055
+}
+
+// D65 sRGB <-> XYZ matrices.
+func rgbToXYZ(r, g, b float64) (x, y, z float64) {
+	rl, gl, bl := srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)
+	x = 0.4124564*rl + 0.3575761*gl + 0.1804375*bl
+	y = 0.2126729*rl + 0.7151522*gl + 0.0721750*bl
+	z = 0.0193339*rl + 0.1191920*gl + 0.9503041*bl
+	return x, y, z
+}
+
+func xyzToRGB(x, y, z float64) (r, g, b float64) {
+	rl := 3.2404542*x - 1.5371385*y - 0.4985314*z
+	gl := -0.9692660*x + 1.8760108*y + 0.0415560*z
+	bl := 0.0556434*x - 0.2040259*y + 1.0572252*z
+	r = linearToSRGB(clamp01(rl))
+	g = linearToSRGB(clamp01(gl))
+	b = linearToSRGB(clamp01(bl))
+	return r, g, b
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+func xyzToXYY(x, y, z float64) (cx, cy, yLum float64) {
+	sum := x + y + z
+	if sum == 0 {
+		return 0, 0, 0
+	}
+	return x / sum, y / sum, y
+}
+
+func xyYToXYZ(cx, cy, yLum float64) (x, y, z float64) {
+	if cy == 0 {
+		return 0, 0, 0
+	}
+	x = (yLum / cy) * cx
+	y = yLum
+	z = (yLum / cy) * (1 - cx - cy)
+	return x, y, z
+}
+
+func rgbToHSV(r, g, b float64) (h, s, v float64) {
+	maxC := math.Max(r, math.Max(g, b))
+	minC := math.Min(r, math.Min(g, b))
+	v = maxC
+	delta := maxC - minC
+	if maxC == 0 {
+		return 0, 0, v
+	}
+	s = delta / maxC
+	if delta == 0 {
+		return 0, s, v
+	}
+	switch maxC {
+	case r:
+		h = 60 * math.Mod((g-b)/delta, 6)
+	case g:
+		h = 60 * ((b-r)/delta + 2)
+	case b:
+		h = 60 * ((r-g)/delta + 4)
+	}
+	if h < 0 {
+		h += 360
+	}
+	return h, s, v
+}
+
+func hsvToRGB(h, s, v float64) (r, g, b float64) {
+	c := v * s
+	hp := math.Mod(h, 360) / 60
+	x := c * (1 - math.Abs(math.Mod(hp, 2)-1))
+	var r1, g1, b1 float64
+	switch {
+	case hp < 1:
+		r1, g1, b1 = c, x, 0
+	case hp < 2:
+		r1, g1, b1 = x, c, 0
+	case hp < 3:
+		r1, g1, b1 = 0, c, x
+	case hp < 4:
+		r1, g1, b1 = 0, x, c
+	case hp < 5:
+		r1, g1, b1 = x, 0, c
+	default:
+		r1, g1, b1 = c, 0, x
+	}
+	m := v - c
+	return r1 + m, g1 + m, b1 + m
+}
+
+// ToRGB returns the color expressed as 8-bit sRGB.
+func (c ColorValue) ToRGB() (uint8, uint8, uint8) {
+	switch c.Kind {
+	case KindRGB:
+		return c.R, c.G, c.B
+	case KindHSV:
+		r, g, b := hsvToRGB(c.H, c.S, c.V)
+		return to8(r), to8(g), to8(b)
+	case KindXY:
+		x, y, z := xyYToXYZ(c.X, c.Y, c.Y_)
+		r, g, b := xyzToRGB(x, y, z)
+		return to8(r), to8(g), to8(b)
+	default:
+		return 0, 0, 0
+	}
+}
+
+// ToHSV returns the color expressed as HSV (h in degrees, s and v in [0,1]).
+func (c ColorValue) ToHSV() (float64, float64, float64) {
+	switch c.Kind {
+	case KindHSV:
+		return c.H, c.S, c.V
+	case KindRGB:
+		return rgbToHSV(from8(c.R), from8(c.G), from8(c.B))
+	case KindXY:
+		r, g, b := c.ToRGB()
+		return rgbToHSV(from8(r), from8(g), from8(b))
+	default:
+		return 0, 0, 0
+	}
+}
+
+// ToXY returns the color expressed as CIE xyY chromaticity plus luminance.
+func (c ColorValue) ToXY() (float64, float64, float64) {
+	switch c.Kind {
+	case KindXY:
+		return c.X, c.Y, c.Y_
+	case KindRGB:
+		x, y, z := rgbToXYZ(from8(c.R), from8(c.G), from8(c.B))
+		return xyzToXYY(x, y, z)
+	case KindHSV:
+		r, g, b := hsvToRGB(c.H, c.S, c.V)
+		x, y, z := rgbToXYZ(r, g, b)
+		return xyzToXYY(x, y, z)
+	default:
+		return 0, 0, 0
+	}
+}
+
+func to8(v float64) uint8 {
+	v = clamp01(v) * 255
+	return uint8(math.Round(v))
+}
+
+func from8(v uint8) float64 {
+	return float64(v) / 255
+}
+
+// MarshalJSON encodes the color as a tagged string, e.g. "rgb:255,70,0",
+// "xy:0.22,0.18", or "hsv:20,1,1".
+func (c ColorValue) MarshalJSON() ([]byte, error) {
+	var s string
+	switch c.Kind {
+	case KindRGB:
+		s = fmt.Sprintf("rgb:%d,%d,%d", c.R, c.G, c.B)
+	case KindHSV:
+		s = fmt.Sprintf("hsv:%s,%s,%s", trim(c.H), trim(c.S), trim(c.V))
+	case KindXY:
+		s = fmt.Sprintf("xy:%s,%s", trim(c.X), trim(c.Y))
+	default:
+		return nil, fmt.Errorf("color: unknown kind %q", c.Kind)
+	}
+	return json.Marshal(s)
+}
+
+func trim(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// UnmarshalJSON parses a tagged string such as "rgb:255,70,0", "xy:0.22,0.18",
+// or "hsv:20,1.0,1.0" into a ColorValue.
+func (c *ColorValue) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("color: %w", err)
+	}
+	kind, rest, ok := strings.Cut(s, ":")
+	if !ok {
+		return fmt.Errorf("color: missing kind tag in %q", s)
+	}
+	parts := strings.Split(rest, ",")
+	switch ColorKind(kind) {
+	case KindRGB:
+		if len(parts) != 3 {
+			return fmt.Errorf("color: rgb requires 3 components, got %q", s)
+		}
+		r, err := parseUint8(parts[0])
+		if err != nil {
+			return err
+		}
+		g, err := parseUint8(parts[1])
+		if err != nil {
+			return err
+		}
+		b, err := parseUint8(parts[2])
+		if err != nil {
+			return err
+		}
+		*c = RGB(r, g, b)
+	case KindHSV:
+		if len(parts) != 3 {
+			return fmt.Errorf("color: hsv requires 3 components, got %q", s)
+		}
+		h, err := strconv.ParseFloat(parts[0], 64)
+		if err != nil {
+			return fmt.Errorf("color: invalid hsv hue in %q: %w", s, err)
+		}
+		sat, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return fmt.Errorf("color: invalid hsv saturation in %q: %w", s, err)
+		}
+		v, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return fmt.Errorf("color: invalid hsv value in %q: %w", s, err)
+		}
+		*c = HSV(h, sat, v)
+	case KindXY:
+		if len(parts) != 2 {
+			return fmt.Errorf("color: xy requires 2 components, got %q", s)
+		}
+		x, err := strconv.ParseFloat(parts[0], 64)
+		if err != nil {
+			return fmt.Errorf("color: invalid xy x in %q: %w", s, err)
+		}
+		y, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return fmt.Errorf("color: invalid xy y in %q: %w", s, err)
+		}
+		*c = XY(x, y, 1)
+	default:
+		return fmt.Errorf("color: unknown kind tag %q in %q", kind, s)
+	}
+	return nil
+}
+
+func parseUint8(s string) (uint8, error) {
+	n, err := strconv.ParseUint(strings.TrimSpace(s), 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("color: invalid rgb component %q: %w", s, err)
+	}
+	return uint8(n), nil
+}
@@ -0,0 +1,95 @@
+package color
+
+import "testing"
+
+func within(a, b uint8, tol int) bool {
+	d := int(a) - int(b)
+	if d < 0 {
+		d = -d
+	}
+	return d <= tol
+}
+
+func TestRGBRoundTripViaHSV(t *testing.T) {
+	cases := []ColorValue{
+		RGB(255, 70, 0),
+		RGB(0, 0, 255),
+		RGB(50, 250, 10),
+		RGB(255, 255, 255),
+		RGB(0, 0, 0),
+	}
+	for _, want := range cases {
+		h, s, v := want.ToHSV()
+		got := HSV(h, s, v)
+		wr, wg, wb := want.ToRGB()
+		gr, gg, gb := got.ToRGB()
+		if !within(wr, gr, 1) || !within(wg, gg, 1) || !within(wb, gb, 1) {
+			t.Errorf("RGB(%d,%d,%d) via HSV round-tripped to (%d,%d,%d)", wr, wg, wb, gr, gg, gb)
+		}
+	}
+}
+
+func TestRGBRoundTripViaXY(t *testing.T) {
+	cases := []ColorValue{
+		RGB(255, 70, 0),
+		RGB(0, 0, 255),
+		RGB(50, 250, 10),
+		RGB(255, 255, 255),
+	}
+	for _, want := range cases {
+		x, y, yLum := want.ToXY()
+		got := XY(x, y, yLum)
+		wr, wg, wb := want.ToRGB()
+		gr, gg, gb := got.ToRGB()
+		if !within(wr, gr, 2) || !within(wg, gg, 2) || !within(wb, gb, 2) {
+			t.Errorf("RGB(%d,%d,%d) via XY round-tripped to (%d,%d,%d)", wr, wg, wb, gr, gg, gb)
+		}
+	}
+}
+
+func TestHSVRoundTripViaRGB(t *testing.T) {
+	cases := []ColorValue{
+		HSV(20, 1, 1),
+		HSV(240, 1, 1),
+		HSV(0, 0, 1),
+		HSV(100, 0.5, 0.75),
+	}
+	for _, want := range cases {
+		r, g, b := want.ToRGB()
+		got := RGB(r, g, b)
+		wh, ws, wv := want.ToHSV()
+		gh, gs, gv := got.ToHSV()
+		if !closeEnough(wh, gh, 2) || !closeEnough(ws, gs, 0.02) || !closeEnough(wv, gv, 0.02) {
+			t.Errorf("HSV(%v,%v,%v) via RGB round-tripped to (%v,%v,%v)", wh, ws, wv, gh, gs, gv)
+		}
+	}
+}
+
+func closeEnough(a, b, tol float64) bool {
+	d := a - b
+	if d < 0 {
+		d = -d
+	}
+	return d <= tol
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	cases := []ColorValue{
+		RGB(255, 70, 0),
+		HSV(20, 1, 1),
+		XY(0.22, 0.18, 1),
+	}
+	for _, want := range cases {
+		data, err := want.MarshalJSON()
+		if err != nil {
+			t.Fatalf("MarshalJSON(%+v): %v", want, err)
+		}
+		var got ColorValue
+		if err := got.UnmarshalJSON(data); err != nil {
+			t.Fatalf("UnmarshalJSON(%s): %v", data, err)
+		}
+		if got != want {
+			t.Errorf("JSON round-trip of %+v produced %+v", want, got)
+		}
+	}
+}
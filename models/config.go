@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// LEDBinding points one configured LED component at the weatherbox: which
+// component to drive, any per-condition preset overrides, and which color
+// space its DoCommand expects.
+type LEDBinding struct {
+	Name           string            `json:"name"`
+	PresetOverride map[string]string `json:"preset-override"`
+	ColorSpace     string            `json:"color-space"`
+}
+
+// UnmarshalJSON folds the legacy singular "led-component" string into
+// LedComponents so existing single-strip configs keep working unchanged.
+func (cfg *Config) UnmarshalJSON(data []byte) error {
+	type alias Config
+	var a alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+	*cfg = Config(a)
+	if len(cfg.LedComponents) == 0 && cfg.LedComponent != "" {
+		cfg.LedComponents = []LEDBinding{{Name: cfg.LedComponent}}
+	}
+	return nil
+}
+
+// normalizedPresetOverride lowercases PresetOverride's condition keys so
+// they match the same case-insensitive lookup resolveConditionSpecs applies
+// to ConditionMap; both maps are keyed by the same condition strings and
+// should agree on case sensitivity.
+func (b LEDBinding) normalizedPresetOverride() map[string]string {
+	if len(b.PresetOverride) == 0 {
+		return b.PresetOverride
+	}
+	normalized := make(map[string]string, len(b.PresetOverride))
+	for condition, presetName := range b.PresetOverride {
+		normalized[strings.ToLower(condition)] = presetName
+	}
+	return normalized
+}
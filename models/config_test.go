@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestConfigUnmarshalJSONLegacyLedComponent(t *testing.T) {
+	var cfg Config
+	raw := []byte(`{"refresh-interval": 60, "weather-sensor": "sensor1", "led-component": "strip1"}`)
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	want := []LEDBinding{{Name: "strip1"}}
+	if !reflect.DeepEqual(cfg.LedComponents, want) {
+		t.Errorf("LedComponents = %+v, want %+v", cfg.LedComponents, want)
+	}
+}
+
+func TestConfigUnmarshalJSONPrefersLedComponents(t *testing.T) {
+	var cfg Config
+	raw := []byte(`{"led-component": "legacy", "led-components": [{"name": "strip1"}, {"name": "strip2"}]}`)
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	want := []LEDBinding{{Name: "strip1"}, {Name: "strip2"}}
+	if !reflect.DeepEqual(cfg.LedComponents, want) {
+		t.Errorf("LedComponents = %+v, want %+v", cfg.LedComponents, want)
+	}
+}
+
+func TestNormalizedPresetOverride(t *testing.T) {
+	b := LEDBinding{PresetOverride: map[string]string{"Sunny/Hot": "warm", "snow_heavy/COLD": "blizzard"}}
+
+	got := b.normalizedPresetOverride()
+	want := map[string]string{"sunny/hot": "warm", "snow_heavy/cold": "blizzard"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizedPresetOverride() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNormalizedPresetOverrideEmpty(t *testing.T) {
+	var b LEDBinding
+	if got := b.normalizedPresetOverride(); len(got) != 0 {
+		t.Errorf("normalizedPresetOverride() on empty override = %+v, want empty", got)
+	}
+}
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of payload carried by an Event.
+type EventType string
+
+const (
+	EventWeatherReading   EventType = "weather_reading"
+	EventConditionChanged EventType = "condition_changed"
+	EventAnimationApplied EventType = "animation_applied"
+	EventSensorError      EventType = "sensor_error"
+)
+
+// Event is a single typed occurrence published on the eventBus.
+type Event struct {
+	Type      EventType
+	Timestamp time.Time
+	Data      any
+}
+
+// WeatherReadingEvent carries the raw sensor reading from a visualizeWeather pass.
+type WeatherReadingEvent struct {
+	Reading map[string]any
+}
+
+// ConditionChangedEvent fires only on a condition transition, never a repeat.
+type ConditionChangedEvent struct {
+	Previous string
+	Current  string
+}
+
+// AnimationAppliedEvent fires once an animation has been sent to the LED component.
+type AnimationAppliedEvent struct {
+	Condition string
+}
+
+// SensorErrorEvent fires when reading the weather sensor fails.
+type SensorErrorEvent struct {
+	Err error
+}
+
+// eventSubscription is a single DoCommand-level subscriber: a buffered
+// channel plus an optional filter over event types.
+type eventSubscription struct {
+	filter map[EventType]bool
+	ch     chan Event
+}
+
+// eventBus is a small fan-out publish/subscribe bus, modeled on the
+// Join/RunEvent pattern used by other Viam drivers' internal event buses.
+// Subscribers never block publishing: a full buffer drops the event rather
+// than stalling visualizeWeather.
+type eventBus struct {
+	mu     sync.Mutex
+	subs   map[string]*eventSubscription
+	nextID int
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subs: make(map[string]*eventSubscription)}
+}
+
+// Join registers a new subscription with the given buffer size and optional
+// event-type filter (an empty filter receives every event), returning its
+// subscription ID.
+func (b *eventBus) Join(buffer int, events []string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := strconv.Itoa(b.nextID)
+
+	var filter map[EventType]bool
+	if len(events) > 0 {
+		filter = make(map[EventType]bool, len(events))
+		for _, e := range events {
+			filter[EventType(e)] = true
+		}
+	}
+
+	b.subs[id] = &eventSubscription{filter: filter, ch: make(chan Event, buffer)}
+	return id
+}
+
+// Leave removes a subscription so it stops receiving events.
+func (b *eventBus) Leave(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subs, id)
+}
+
+// RunEvent publishes an event to every subscription whose filter accepts it.
+func (b *eventBus) RunEvent(evt Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sub := range b.subs {
+		if sub.filter != nil && !sub.filter[evt.Type] {
+			continue
+		}
+		select {
+		case sub.ch <- evt:
+		default:
+			// Buffer full; drop rather than block the publisher.
+		}
+	}
+}
+
+// Chan returns the raw channel backing a subscription, for callers that can
+// push-deliver events as they arrive instead of polling for them (see
+// weatherboxServiceService.StreamEvents). The bool return is false if the
+// subscription ID is unknown. The returned channel is never closed by Leave;
+// callers should stop reading from it once they're done subscribing.
+func (b *eventBus) Chan(id string) (<-chan Event, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sub, ok := b.subs[id]
+	if !ok {
+		return nil, false
+	}
+	return sub.ch, true
+}
+
+// Poll drains every buffered event for a subscription. The bool return is
+// false if the subscription ID is unknown.
+func (b *eventBus) Poll(id string) ([]Event, bool) {
+	b.mu.Lock()
+	sub, ok := b.subs[id]
+	b.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	var drained []Event
+	for {
+		select {
+		case evt := <-sub.ch:
+			drained = append(drained, evt)
+		default:
+			return drained, true
+		}
+	}
+}
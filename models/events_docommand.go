@@ -0,0 +1,66 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// subscribeRequest is the payload for DoCommand's "subscribe" key, e.g.
+// {"subscribe": {"buffer": 32, "events": ["condition_changed"]}}.
+type subscribeRequest struct {
+	Buffer int      `json:"buffer"`
+	Events []string `json:"events"`
+}
+
+// handleSubscribe registers a new event subscription and returns its ID.
+func (s *weatherboxServiceService) handleSubscribe(raw any) (map[string]any, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe: invalid payload: %w", err)
+	}
+	req := subscribeRequest{Buffer: 32}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("subscribe: invalid payload: %w", err)
+	}
+	if req.Buffer <= 0 {
+		req.Buffer = 32
+	}
+
+	id := s.events.Join(req.Buffer, req.Events)
+	return map[string]any{"subscription_id": id}, nil
+}
+
+// handleUnsubscribe removes a subscription so it stops receiving events and
+// its map entry can be garbage collected.
+func (s *weatherboxServiceService) handleUnsubscribe(raw any) (map[string]any, error) {
+	id, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("unsubscribe: expected a subscription id string")
+	}
+
+	s.events.Leave(id)
+	return map[string]any{"unsubscribed": id}, nil
+}
+
+// handlePoll drains buffered events for a subscription ID.
+func (s *weatherboxServiceService) handlePoll(raw any) (map[string]any, error) {
+	id, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("poll: expected a subscription id string")
+	}
+
+	evts, ok := s.events.Poll(id)
+	if !ok {
+		return nil, fmt.Errorf("poll: unknown subscription id %q", id)
+	}
+
+	out := make([]map[string]any, len(evts))
+	for i, evt := range evts {
+		out[i] = map[string]any{
+			"type":      string(evt.Type),
+			"timestamp": evt.Timestamp,
+			"data":      evt.Data,
+		}
+	}
+	return map[string]any{"events": out}, nil
+}
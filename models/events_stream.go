@@ -0,0 +1,37 @@
+package models
+
+import (
+	"context"
+	"fmt"
+)
+
+// StreamEvents push-delivers events for an existing subscription by invoking
+// send for each one as it arrives, until ctx is canceled or send returns an
+// error.
+//
+// KNOWN LIMITATION, not yet implemented: this is not exposed as a gRPC
+// server-streaming RPC, so no external consumer can actually receive push
+// delivery today. The service registers under generic.API, whose DoCommand
+// is unary-only, so there's no proto method to stream over without defining
+// a new service API and regenerating Viam's gRPC bindings -- tracked as
+// follow-up work, not done here. StreamEvents is the underlying push
+// primitive such an RPC would forward into Send() once that API exists; in
+// the meantime subscribe/poll (events_docommand.go) are the only way an
+// external caller can consume events, by polling.
+func (s *weatherboxServiceService) StreamEvents(ctx context.Context, subscriptionID string, send func(Event) error) error {
+	ch, ok := s.events.Chan(subscriptionID)
+	if !ok {
+		return fmt.Errorf("stream_events: unknown subscription id %q", subscriptionID)
+	}
+	defer s.events.Leave(subscriptionID)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case evt := <-ch:
+			if err := send(evt); err != nil {
+				return err
+			}
+		}
+	}
+}
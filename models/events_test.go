@@ -0,0 +1,38 @@
+package models
+
+import "testing"
+
+func TestEventBusLeaveStopsDelivery(t *testing.T) {
+	b := newEventBus()
+	id := b.Join(4, nil)
+
+	b.RunEvent(Event{Type: EventWeatherReading})
+	if evts, ok := b.Poll(id); !ok || len(evts) != 1 {
+		t.Fatalf("expected 1 buffered event before Leave, got %v, ok=%v", evts, ok)
+	}
+
+	b.Leave(id)
+	b.RunEvent(Event{Type: EventWeatherReading})
+
+	if _, ok := b.Poll(id); ok {
+		t.Fatalf("expected subscription %q to be gone after Leave", id)
+	}
+	if _, ok := b.Chan(id); ok {
+		t.Fatalf("expected Chan to report subscription %q unknown after Leave", id)
+	}
+}
+
+func TestHandleUnsubscribeRemovesSubscription(t *testing.T) {
+	s := &weatherboxServiceService{events: newEventBus()}
+	id := s.events.Join(4, nil)
+
+	if _, err := s.handleUnsubscribe(id); err != nil {
+		t.Fatalf("handleUnsubscribe: %v", err)
+	}
+	if _, ok := s.events.Poll(id); ok {
+		t.Fatalf("expected subscription %q to be gone after unsubscribe", id)
+	}
+	if _, err := s.handleUnsubscribe(id); err != nil {
+		t.Fatalf("handleUnsubscribe on already-removed id should not error: %v", err)
+	}
+}
@@ -3,13 +3,12 @@ package models
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/benbjohnson/clock"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 
 	genericComponent "go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/components/sensor"
@@ -17,11 +16,12 @@ import (
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/services/generic"
 	"go.viam.com/utils/rpc"
+
+	"weatherbox-service/color"
 )
 
 const (
 	period            = 3
-	duration          = period
 	animationDuration = time.Millisecond*1000*period + 10
 
 	hot  = 65.0
@@ -29,57 +29,91 @@ const (
 )
 
 var (
-	orange  = []any{255, 70, 0}
-	red     = []any{255, 0, 120}
-	magenta = []any{255, 0, 5}
-
-	purple = []any{150, 0, 255}
-	blue   = []any{0, 0, 255}
-	green  = []any{50, 250, 10}
-	cyan   = []any{69, 255, 226}
-	white  = []any{255, 255, 255}
+	orange  = color.RGB(255, 70, 0)
+	red     = color.RGB(255, 0, 120)
+	magenta = color.RGB(255, 0, 5)
+
+	purple = color.RGB(150, 0, 255)
+	blue   = color.RGB(0, 0, 255)
+	green  = color.RGB(50, 250, 10)
+	cyan   = color.RGB(69, 255, 226)
+	white  = color.RGB(255, 255, 255)
+	yellow = color.RGB(255, 255, 0)
 )
 
-func generateSequence(colors [][]any) map[string]any {
-	sequences := make(map[string]any)
-
-	// Generate three sequences with different starting points
-	for i := 0; i < 3; i++ {
-		animations := make([]map[string]any, len(colors))
-
-		// Create animations array with rotated colors
-		for j := 0; j < len(colors); j++ {
-			colorIndex := (i + j) % len(colors)
-			animations[j] = map[string]any{
-				"set_animation": "pulse",
-				"speed":         0.001,
-				"period":        period,
-				"colors":        []any{colors[colorIndex]},
-			}
-		}
-
-		// Create sequence for this rotation
-		sequences[strconv.Itoa(i)] = map[string]any{
-			"sequence": map[string]any{
-				"animations": animations,
-				"duration":   duration,
-			},
-		}
-	}
-
-	return sequences
-}
-
 var (
-	Service      = resource.NewModel("vijayvuyyuru", "weatherbox-service", "service")
-	animationMap = map[string]map[string]any{
-		"sunny/hot":   generateSequence([][]any{orange, red, magenta}),
-		"sunny/cold":  generateSequence([][]any{orange, purple, blue}),
-		"cloudy/hot":  generateSequence([][]any{white, magenta, red}),
-		"cloudy/cold": generateSequence([][]any{white, purple, blue}),
-		"rainy/hot":   generateSequence([][]any{cyan, magenta, red}),
-		"none":        generateSequence([][]any{green, magenta, red}),
-		"all":         generateSequence([][]any{magenta, purple, orange}),
+	Service = resource.NewModel("vijayvuyyuru", "weatherbox-service", "service")
+
+	// defaultAnimations are the built-in per-condition palettes, kept as bare
+	// PresetSpecs (no LEDBackend baked in) so each bound LED can render them
+	// in its own configured color space instead of a single shared one. Keys
+	// are "<getCondition bucket>/<tempBand>", so every bucket getCondition can
+	// return is covered for all three temp bands -- handleWeatherCondition
+	// does a bare map lookup with no further fallback, so a missing entry
+	// here means a deployment without AnimationPresets/ConditionMap silently
+	// stops updating the LEDs for that condition.
+	defaultAnimations = map[string]PresetSpec{
+		"sunny/hot":  {Colors: []color.ColorValue{orange, red, magenta}},
+		"sunny/mild": {Colors: []color.ColorValue{orange, red, blue}},
+		"sunny/cold": {Colors: []color.ColorValue{orange, purple, blue}},
+
+		"partly_cloudy/hot":  {Colors: []color.ColorValue{white, orange, red}},
+		"partly_cloudy/mild": {Colors: []color.ColorValue{white, orange, blue}},
+		"partly_cloudy/cold": {Colors: []color.ColorValue{white, purple, blue}},
+
+		"cloudy/hot":  {Colors: []color.ColorValue{white, magenta, red}},
+		"cloudy/mild": {Colors: []color.ColorValue{white, magenta, blue}},
+		"cloudy/cold": {Colors: []color.ColorValue{white, purple, blue}},
+
+		"mist_fog/hot":  {Colors: []color.ColorValue{white, cyan, magenta}},
+		"mist_fog/mild": {Colors: []color.ColorValue{white, cyan, blue}},
+		"mist_fog/cold": {Colors: []color.ColorValue{white, cyan, purple}},
+
+		"drizzle/hot":  {Colors: []color.ColorValue{cyan, blue, magenta}},
+		"drizzle/mild": {Colors: []color.ColorValue{cyan, blue, purple}},
+		"drizzle/cold": {Colors: []color.ColorValue{cyan, blue, white}},
+
+		"rain_light/hot":  {Colors: []color.ColorValue{cyan, magenta, red}},
+		"rain_light/mild": {Colors: []color.ColorValue{cyan, blue, magenta}},
+		"rain_light/cold": {Colors: []color.ColorValue{cyan, blue, purple}},
+
+		"rain_moderate/hot":  {Colors: []color.ColorValue{blue, magenta, red}},
+		"rain_moderate/mild": {Colors: []color.ColorValue{blue, cyan, purple}},
+		"rain_moderate/cold": {Colors: []color.ColorValue{blue, purple, white}},
+
+		"rain_heavy/hot":  {Colors: []color.ColorValue{blue, red, magenta}},
+		"rain_heavy/mild": {Colors: []color.ColorValue{blue, purple, magenta}},
+		"rain_heavy/cold": {Colors: []color.ColorValue{blue, purple, white}},
+
+		"snow_light/hot":  {Colors: []color.ColorValue{white, cyan, blue}},
+		"snow_light/mild": {Colors: []color.ColorValue{white, blue, purple}},
+		"snow_light/cold": {Colors: []color.ColorValue{white, blue, purple}},
+
+		"snow_moderate/hot":  {Colors: []color.ColorValue{white, cyan, blue}},
+		"snow_moderate/mild": {Colors: []color.ColorValue{white, purple, blue}},
+		"snow_moderate/cold": {Colors: []color.ColorValue{white, purple, blue}},
+
+		"snow_heavy/hot":  {Colors: []color.ColorValue{white, cyan, blue}},
+		"snow_heavy/mild": {Colors: []color.ColorValue{white, purple, blue}},
+		"snow_heavy/cold": {Colors: []color.ColorValue{white, purple, blue}},
+
+		"sleet/hot":  {Colors: []color.ColorValue{white, blue, cyan}},
+		"sleet/mild": {Colors: []color.ColorValue{white, blue, purple}},
+		"sleet/cold": {Colors: []color.ColorValue{white, blue, purple}},
+
+		"thunder/hot":  {Colors: []color.ColorValue{purple, yellow, magenta}},
+		"thunder/mild": {Colors: []color.ColorValue{purple, yellow, blue}},
+		"thunder/cold": {Colors: []color.ColorValue{purple, yellow, white}},
+
+		"ice_pellets/hot":  {Colors: []color.ColorValue{white, cyan, blue}},
+		"ice_pellets/mild": {Colors: []color.ColorValue{white, cyan, purple}},
+		"ice_pellets/cold": {Colors: []color.ColorValue{white, cyan, purple}},
+
+		"none/hot":  {Colors: []color.ColorValue{green, magenta, red}},
+		"none/mild": {Colors: []color.ColorValue{green, magenta, orange}},
+		"none/cold": {Colors: []color.ColorValue{green, purple, blue}},
+
+		"all": {Colors: []color.ColorValue{magenta, purple, orange}},
 	}
 )
 
@@ -94,7 +128,22 @@ func init() {
 type Config struct {
 	RefreshInterval int    `json:"refresh-interval"`
 	WeatherSensor   string `json:"weather-sensor"`
-	LedComponent    string `json:"led-component"`
+
+	// LedComponents fans the animation out to every bound LED component in
+	// parallel, each with its own preset overrides and color space.
+	// LedComponent (singular) is accepted for backward compatibility and is
+	// folded into LedComponents by UnmarshalJSON.
+	LedComponents []LEDBinding `json:"led-components"`
+	LedComponent  string       `json:"led-component"`
+
+	// AnimationPresets and ConditionMap are optional; when both are set,
+	// they replace the built-in defaultAnimations. AnimationPresets names
+	// reusable palettes, and ConditionMap points each condition (e.g.
+	// "sunny/hot") at one of those names. A ConditionMap naming a preset that
+	// AnimationPresets lacks is a config error; AnimationPresets alone keeps
+	// the defaults and only serves LEDBinding preset overrides.
+	AnimationPresets map[string]PresetSpec `json:"animation-presets"`
+	ConditionMap     map[string]string     `json:"condition-map"`
 }
 
 func (cfg *Config) Validate(path string) ([]string, []string, error) {
@@ -105,10 +154,17 @@ func (cfg *Config) Validate(path string) ([]string, []string, error) {
 	if cfg.WeatherSensor == "" {
 		return nil, nil, fmt.Errorf(`expected "weather-sensor" attribute for weather module`)
 	}
-	if cfg.LedComponent == "" {
-		return nil, nil, fmt.Errorf(`expected "led-component" attribute for weather module`)
+	if len(cfg.LedComponents) == 0 {
+		return nil, nil, fmt.Errorf(`expected "led-components" (or legacy "led-component") attribute for weather module`)
+	}
+	deps := []string{cfg.WeatherSensor}
+	for _, binding := range cfg.LedComponents {
+		if binding.Name == "" {
+			return nil, nil, fmt.Errorf(`expected "name" on every entry in "led-components"`)
+		}
+		deps = append(deps, binding.Name)
 	}
-	return nil, []string{cfg.WeatherSensor, cfg.LedComponent}, nil
+	return nil, deps, nil
 }
 
 type weatherboxServiceService struct {
@@ -125,8 +181,29 @@ type weatherboxServiceService struct {
 	ledWg         sync.WaitGroup
 
 	weatherSensor   sensor.Sensor
-	ledComponent    resource.Resource
+	sensorSource    sensorSource
+	sensorSubCancel func()
+	ledComponents   []boundLED
 	refreshInterval time.Duration
+
+	animationMapMu     sync.RWMutex
+	resolvedAnimations map[string]PresetSpec
+	presets            map[string]PresetSpec
+
+	events        *eventBus
+	lastCondition string
+
+	statusMu    sync.RWMutex
+	lastReading map[string]any
+	lastErr     error
+}
+
+// boundLED pairs a resolved LED component with the binding config that
+// produced it, so handleWeatherCondition can apply per-device overrides.
+type boundLED struct {
+	name      string
+	component resource.Resource
+	binding   LEDBinding
 }
 
 func newWeatherboxServiceService(ctx context.Context, deps resource.Dependencies, rawConf resource.Config, logger logging.Logger) (resource.Resource, error) {
@@ -143,6 +220,7 @@ func newWeatherboxServiceService(ctx context.Context, deps resource.Dependencies
 		cfg:        conf,
 		cancelCtx:  cancelCtx,
 		cancelFunc: cancelFunc,
+		events:     newEventBus(),
 	}
 	if err = s.Reconfigure(ctx, deps, rawConf); err != nil {
 		return nil, err
@@ -166,12 +244,48 @@ func (s *weatherboxServiceService) Reconfigure(ctx context.Context, deps resourc
 	if err != nil {
 		return errors.Wrapf(err, "unable to get weather sensor %v for service", config.WeatherSensor)
 	}
-	s.ledComponent, err = genericComponent.FromDependencies(deps, config.LedComponent)
-	if err != nil {
-		return errors.Wrapf(err, "unable to get led component %v for service", config.LedComponent)
+
+	ledComponents := make([]boundLED, 0, len(config.LedComponents))
+	for _, binding := range config.LedComponents {
+		component, err := genericComponent.FromDependencies(deps, binding.Name)
+		if err != nil {
+			return errors.Wrapf(err, "unable to get led component %v for service", binding.Name)
+		}
+		binding.PresetOverride = binding.normalizedPresetOverride()
+		ledComponents = append(ledComponents, boundLED{name: binding.Name, component: component, binding: binding})
 	}
+	s.ledComponents = ledComponents
 	s.refreshInterval = time.Second * time.Duration(config.RefreshInterval)
 
+	if s.sensorSubCancel != nil {
+		s.sensorSubCancel()
+		s.sensorSubCancel = nil
+	}
+	if streaming, ok := s.weatherSensor.(streamingSensor); ok {
+		subCtx, subCancel := context.WithCancel(s.cancelCtx)
+		upstream, err := streaming.SubscribeReadings(subCtx)
+		if err != nil {
+			subCancel()
+			return errors.Wrapf(err, "unable to subscribe to streaming weather sensor %v", config.WeatherSensor)
+		}
+		s.sensorSubCancel = subCancel
+		s.sensorSource = newPushSource(upstream)
+	} else {
+		s.sensorSource = newPollingSource(s.weatherSensor, s.refreshInterval, s.logger)
+	}
+
+	resolvedAnimations := defaultAnimations
+	if len(config.ConditionMap) > 0 {
+		resolvedAnimations, err = resolveConditionSpecs(config.AnimationPresets, config.ConditionMap)
+		if err != nil {
+			return errors.Wrap(err, "invalid animation-presets/condition-map")
+		}
+	}
+	s.animationMapMu.Lock()
+	s.resolvedAnimations = resolvedAnimations
+	s.presets = config.AnimationPresets
+	s.animationMapMu.Unlock()
+
 	if s.ledCancelFunc != nil {
 		s.ledCancelFunc()
 		s.ledWg.Wait()
@@ -188,6 +302,21 @@ func (s *weatherboxServiceService) NewClientFromConn(ctx context.Context, conn r
 }
 
 func (s *weatherboxServiceService) DoCommand(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	if raw, ok := cmd["reload_presets"]; ok {
+		return s.reloadPresets(raw)
+	}
+	if raw, ok := cmd["subscribe"]; ok {
+		return s.handleSubscribe(raw)
+	}
+	if raw, ok := cmd["poll"]; ok {
+		return s.handlePoll(raw)
+	}
+	if raw, ok := cmd["unsubscribe"]; ok {
+		return s.handleUnsubscribe(raw)
+	}
+	if _, ok := cmd["status"]; ok {
+		return s.status(), nil
+	}
 	state, ok := cmd["state"]
 	if ok {
 		if state == "start" {
@@ -197,7 +326,7 @@ func (s *weatherboxServiceService) DoCommand(ctx context.Context, cmd map[string
 			s.ledUpdateCtx, s.ledCancelFunc = context.WithCancel(s.cancelCtx)
 			s.ledWg.Add(1)
 			go func() {
-				s.startWeatherVizService(s.ledUpdateCtx, s.refreshInterval)
+				s.startWeatherVizService(s.ledUpdateCtx)
 			}()
 			return map[string]any{"started": "true"}, nil
 		} else if state == "stop" {
@@ -214,34 +343,57 @@ func (s *weatherboxServiceService) DoCommand(ctx context.Context, cmd map[string
 	return map[string]any{}, nil
 }
 
-func (s *weatherboxServiceService) startWeatherVizService(ctx context.Context, interval time.Duration) {
-	clk := clock.New()
-	t := clk.Ticker(interval)
-	defer t.Stop()
+func (s *weatherboxServiceService) startWeatherVizService(ctx context.Context) {
 	defer s.ledWg.Done()
 	s.logger.Info("starting weather visualization service")
-	s.visualizeWeather(ctx)
-	for {
-		if err := ctx.Err(); err != nil {
-			return
-		}
-		select {
-		case <-ctx.Done():
-			return
-		case <-t.C:
-			s.visualizeWeather(ctx)
-		}
+	for reading := range s.sensorSource.Subscribe(ctx) {
+		s.visualizeWeather(ctx, reading)
 	}
 }
 
-func (s *weatherboxServiceService) visualizeWeather(ctx context.Context) {
-	reading, err := s.weatherSensor.Readings(ctx, map[string]interface{}{})
-	if err != nil {
-		s.logger.Error("error reading weather sensor", "error", err)
+// status reports the fields DoCommand({"status": true}) exposes: the
+// last-good reading, the last read error (if any), the current condition,
+// and, for a polling source, the current backoff step (the delay that a
+// further failed read would wait, before jitter).
+func (s *weatherboxServiceService) status() map[string]any {
+	s.statusMu.RLock()
+	lastReading := s.lastReading
+	var lastErr string
+	if s.lastErr != nil {
+		lastErr = s.lastErr.Error()
+	}
+	lastCondition := s.lastCondition
+	s.statusMu.RUnlock()
+
+	out := map[string]any{
+		"last_reading": lastReading,
+		"last_error":   lastErr,
+		"condition":    lastCondition,
+	}
+	if ps, ok := s.sensorSource.(*pollingSource); ok {
+		out["backoff"] = ps.backoff.Current().String()
+	}
+	return out
+}
+
+func (s *weatherboxServiceService) visualizeWeather(ctx context.Context, reading Reading) {
+	if reading.Err != nil {
+		s.logger.Error("error reading weather sensor", "error", reading.Err)
+		s.statusMu.Lock()
+		s.lastErr = reading.Err
+		s.statusMu.Unlock()
+		s.events.RunEvent(Event{Type: EventSensorError, Timestamp: time.Now(), Data: SensorErrorEvent{Err: reading.Err}})
 		return
 	}
-	s.logger.Info("weather reading", "reading", reading)
-	codeRaw, ok := reading["code"]
+	data := reading.Data
+	s.logger.Info("weather reading", "reading", data)
+	s.statusMu.Lock()
+	s.lastErr = nil
+	s.lastReading = data
+	s.statusMu.Unlock()
+	s.events.RunEvent(Event{Type: EventWeatherReading, Timestamp: time.Now(), Data: WeatherReadingEvent{Reading: data}})
+
+	codeRaw, ok := data["code"]
 	if !ok {
 		s.logger.Error("no condition reading from weather sensor")
 		return
@@ -253,7 +405,7 @@ func (s *weatherboxServiceService) visualizeWeather(ctx context.Context) {
 	}
 	condition := getCondition(code)
 
-	tempOutsideRaw, ok := reading["outside_f"]
+	tempOutsideRaw, ok := data["outside_f"]
 	if !ok {
 		s.logger.Error("no outside temperature reading from weather sensor")
 		return
@@ -264,46 +416,185 @@ func (s *weatherboxServiceService) visualizeWeather(ctx context.Context) {
 		return
 	}
 
-	tempString := "hot"
-	if tempOutside > hot {
-		tempString = "cold"
+	tempString := tempBand(tempOutside)
+	s.logger.Infof("temp band: %v", tempString)
+	conditionKey := condition + "/" + tempString
+
+	s.statusMu.Lock()
+	previousCondition := s.lastCondition
+	if conditionKey != previousCondition {
+		s.lastCondition = conditionKey
+	}
+	s.statusMu.Unlock()
+
+	if conditionKey != previousCondition {
+		s.events.RunEvent(Event{
+			Type:      EventConditionChanged,
+			Timestamp: time.Now(),
+			Data:      ConditionChangedEvent{Previous: previousCondition, Current: conditionKey},
+		})
+	}
+
+	s.handleWeatherCondition(ctx, conditionKey)
+}
+
+// tempBand buckets an outside temperature (in F) into cold (<33), mild
+// (33-65), or hot (>65).
+func tempBand(tempOutside float64) string {
+	switch {
+	case tempOutside < cold:
+		return "cold"
+	case tempOutside > hot:
+		return "hot"
+	default:
+		return "mild"
 	}
-	fmt.Println("tempString", tempString)
-	s.handleWeatherCondition(ctx, condition+"/"+tempString)
 }
 
+// conditionCodes maps WeatherAPI condition codes to a coarser condition key
+// used to address the animation/preset map. Codes not present resolve to
+// "none".
+var conditionCodes = map[float64]string{
+	1000: "sunny",
+
+	1003: "partly_cloudy",
+
+	1006: "cloudy",
+	1009: "cloudy",
+
+	1030: "mist_fog",
+	1135: "mist_fog",
+	1147: "mist_fog",
+
+	1150: "drizzle",
+	1153: "drizzle",
+	1168: "drizzle",
+	1171: "drizzle",
+	1072: "drizzle",
+
+	1063: "rain_light",
+	1180: "rain_light",
+	1183: "rain_light",
+	1198: "rain_light",
+	1240: "rain_light",
+
+	1186: "rain_moderate",
+	1189: "rain_moderate",
+	1201: "rain_moderate",
+
+	1192: "rain_heavy",
+	1195: "rain_heavy",
+	1243: "rain_heavy",
+	1246: "rain_heavy",
+
+	1066: "snow_light",
+	1210: "snow_light",
+	1213: "snow_light",
+	1255: "snow_light",
+
+	1216: "snow_moderate",
+	1219: "snow_moderate",
+	1258: "snow_moderate",
+
+	1114: "snow_heavy",
+	1117: "snow_heavy",
+	1222: "snow_heavy",
+	1225: "snow_heavy",
+
+	1069: "sleet",
+	1204: "sleet",
+	1207: "sleet",
+	1249: "sleet",
+	1252: "sleet",
+
+	1087: "thunder",
+	1273: "thunder",
+	1276: "thunder",
+	1279: "thunder",
+	1282: "thunder",
+
+	1237: "ice_pellets",
+	1261: "ice_pellets",
+	1264: "ice_pellets",
+}
+
+// getCondition classifies a WeatherAPI condition code into one of the keys
+// in conditionCodes, falling back to "none" for unmapped codes.
 func getCondition(code float64) string {
-	switch code {
-	case 1000, 1003:
-		return "sunny"
-	case 1006, 1009, 1030, 1135, 1147:
-		return "cloudy"
-	case 1063, 1066, 1069, 1072, 1087, 1550, 1153,
-		1168, 1171, 1180, 1183, 1186, 1189, 1192, 1195,
-		1198, 1201, 1204, 1207, 1240, 1243, 1246, 1249,
-		1252, 1273, 1276, 1279, 1282:
-		return "rainy"
+	if condition, ok := conditionCodes[code]; ok {
+		return condition
 	}
 	return "none"
 }
 
+// handleWeatherCondition fans the animation for condition out to every
+// bound LED component in parallel, the way the Hue2 bridge applies state
+// to many services at once. Each component can override the preset used
+// for this condition and can request its own color space. Components run
+// against the original ctx rather than one derived from the errgroup, so
+// one component erroring doesn't cancel the DoCommand already in flight to
+// an unrelated component; errgroup here is only for aggregating completion.
 func (s *weatherboxServiceService) handleWeatherCondition(ctx context.Context, condition string) {
-	animations, exists := animationMap[strings.ToLower(condition)]
+	condition = strings.ToLower(condition)
+
+	s.animationMapMu.RLock()
+	spec, exists := s.resolvedAnimations[condition]
+	s.animationMapMu.RUnlock()
 	if !exists {
 		s.logger.Error("no animations found for condition", "condition", condition)
 		return
 	}
-	_, err := s.ledComponent.DoCommand(ctx, animations)
-	if err != nil {
-		s.logger.Error("error setting led colors", "error", err)
+
+	g := new(errgroup.Group)
+	for _, led := range s.ledComponents {
+		led := led
+		g.Go(func() error {
+			animSpec := s.specFor(led, condition, spec)
+			animations := generatePresetSequence(animSpec, color.BackendForColorSpace(led.binding.ColorSpace))
+			if _, err := led.component.DoCommand(ctx, animations); err != nil {
+				s.logger.Errorw("error setting led colors", "error", err, "component", led.name)
+				return err
+			}
+			s.logger.Infow("led colors set for condition", "condition", condition, "component", led.name)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
 		return
 	}
-	s.logger.Infow("led colors set for condition", "condition", condition)
+
+	s.events.RunEvent(Event{Type: EventAnimationApplied, Timestamp: time.Now(), Data: AnimationAppliedEvent{Condition: condition}})
+}
+
+// specFor resolves which PresetSpec a specific LED binding should animate
+// for condition: its preset override if one exists and is valid, otherwise
+// the condition's default spec. It intentionally does not bake a backend in
+// — the caller does that per binding, so every binding (override or not)
+// gets colors rendered in the color space it actually expects.
+func (s *weatherboxServiceService) specFor(led boundLED, condition string, fallback PresetSpec) PresetSpec {
+	presetName, ok := led.binding.PresetOverride[condition]
+	if !ok {
+		return fallback
+	}
+
+	s.animationMapMu.RLock()
+	spec, ok := s.presets[presetName]
+	s.animationMapMu.RUnlock()
+	if !ok {
+		s.logger.Errorw("preset-override references unknown preset, using default", "component", led.name, "preset", presetName)
+		return fallback
+	}
+	return spec
 }
 
 func (s *weatherboxServiceService) Close(context.Context) error {
 	s.cancelFunc()
 
+	if s.sensorSubCancel != nil {
+		s.sensorSubCancel()
+		s.sensorSubCancel = nil
+	}
+
 	if s.ledCancelFunc != nil {
 		s.ledCancelFunc()
 		s.ledWg.Wait()
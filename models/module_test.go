@@ -0,0 +1,97 @@
+package models
+
+import "testing"
+
+// wantConditionCodes is a hardcoded, independent enumeration of the full
+// WeatherAPI condition-code taxonomy this package classifies. It is not
+// derived from conditionCodes, so a future edit that drops or mis-buckets a
+// code (as happened with 1063/1072) fails this test instead of vacuously
+// passing against itself.
+var wantConditionCodes = map[float64]string{
+	1000: "sunny",
+	1003: "partly_cloudy",
+	1006: "cloudy",
+	1009: "cloudy",
+	1030: "mist_fog",
+	1135: "mist_fog",
+	1147: "mist_fog",
+	1150: "drizzle",
+	1153: "drizzle",
+	1168: "drizzle",
+	1171: "drizzle",
+	1072: "drizzle",
+	1063: "rain_light",
+	1180: "rain_light",
+	1183: "rain_light",
+	1198: "rain_light",
+	1240: "rain_light",
+	1186: "rain_moderate",
+	1189: "rain_moderate",
+	1201: "rain_moderate",
+	1192: "rain_heavy",
+	1195: "rain_heavy",
+	1243: "rain_heavy",
+	1246: "rain_heavy",
+	1066: "snow_light",
+	1210: "snow_light",
+	1213: "snow_light",
+	1255: "snow_light",
+	1216: "snow_moderate",
+	1219: "snow_moderate",
+	1258: "snow_moderate",
+	1114: "snow_heavy",
+	1117: "snow_heavy",
+	1222: "snow_heavy",
+	1225: "snow_heavy",
+	1069: "sleet",
+	1204: "sleet",
+	1207: "sleet",
+	1249: "sleet",
+	1252: "sleet",
+	1087: "thunder",
+	1273: "thunder",
+	1276: "thunder",
+	1279: "thunder",
+	1282: "thunder",
+	1237: "ice_pellets",
+	1261: "ice_pellets",
+	1264: "ice_pellets",
+}
+
+func TestGetCondition(t *testing.T) {
+	if len(conditionCodes) != len(wantConditionCodes) {
+		t.Fatalf("conditionCodes has %d entries, want %d", len(conditionCodes), len(wantConditionCodes))
+	}
+	for code, want := range wantConditionCodes {
+		if got := getCondition(code); got != want {
+			t.Errorf("getCondition(%v) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestGetConditionUnmapped(t *testing.T) {
+	if got := getCondition(9999); got != "none" {
+		t.Errorf(`getCondition(9999) = %q, want "none"`, got)
+	}
+}
+
+// TestDefaultAnimationsCoverEveryBucket guards against defaultAnimations
+// drifting out of sync with getCondition's taxonomy: handleWeatherCondition
+// does a bare lookup with no further fallback, so a missing "bucket/band"
+// entry silently stops updating the LEDs for any deployment that doesn't
+// set AnimationPresets/ConditionMap.
+func TestDefaultAnimationsCoverEveryBucket(t *testing.T) {
+	buckets := map[string]bool{"none": true}
+	for _, bucket := range conditionCodes {
+		buckets[bucket] = true
+	}
+
+	for bucket := range buckets {
+		for _, band := range []string{"hot", "mild", "cold"} {
+			key := bucket + "/" + band
+			if _, ok := defaultAnimations[key]; !ok {
+				t.Errorf("defaultAnimations missing entry for %q", key)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"weatherbox-service/color"
+)
+
+// reloadPresetsRequest mirrors the Config fields that DoCommand's
+// reload_presets accepts, so a caller can swap the animation map without a
+// full Reconfigure.
+type reloadPresetsRequest struct {
+	AnimationPresets map[string]PresetSpec `json:"animation-presets"`
+	ConditionMap     map[string]string     `json:"condition-map"`
+}
+
+// reloadPresets rebuilds the animation map from a DoCommand payload and
+// swaps it in atomically, so existing animations keep running on the old
+// map until the new one is fully validated.
+func (s *weatherboxServiceService) reloadPresets(raw any) (map[string]any, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("reload_presets: invalid payload: %w", err)
+	}
+	var req reloadPresetsRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("reload_presets: invalid payload: %w", err)
+	}
+	built, err := resolveConditionSpecs(req.AnimationPresets, req.ConditionMap)
+	if err != nil {
+		return nil, fmt.Errorf("reload_presets: %w", err)
+	}
+
+	s.animationMapMu.Lock()
+	s.resolvedAnimations = built
+	s.presets = req.AnimationPresets
+	s.animationMapMu.Unlock()
+
+	return map[string]any{"reloaded": "true", "conditions": len(built)}, nil
+}
+
+// PresetSpec describes a named animation palette that a ConditionMap entry
+// can reference, so users can reconfigure which colors and animation style
+// apply to a condition without recompiling the module.
+type PresetSpec struct {
+	Colors        []color.ColorValue `json:"colors"`
+	AnimationType string             `json:"animation-type"`
+	Speed         float64            `json:"speed"`
+	Period        int                `json:"period"`
+	Duration      time.Duration      `json:"duration"`
+}
+
+// resolveConditionSpecs resolves a ConditionMap of condition -> preset name
+// into the PresetSpec handleWeatherCondition looks up per condition,
+// validating that every referenced preset exists and has at least one
+// color. The spec is returned bare (no LEDBackend baked in) so each bound
+// LED renders it in its own configured color space.
+func resolveConditionSpecs(presets map[string]PresetSpec, conditionMap map[string]string) (map[string]PresetSpec, error) {
+	resolved := make(map[string]PresetSpec, len(conditionMap))
+	for condition, presetName := range conditionMap {
+		spec, ok := presets[presetName]
+		if !ok {
+			return nil, fmt.Errorf("condition-map references unknown preset %q for condition %q", presetName, condition)
+		}
+		if len(spec.Colors) == 0 {
+			return nil, fmt.Errorf("preset %q has no colors", presetName)
+		}
+		resolved[strings.ToLower(condition)] = spec
+	}
+	return resolved, nil
+}
+
+// generatePresetSequence builds the three rotated animation sequences for a
+// PresetSpec's colors, animation type, speed, and period (falling back to
+// package defaults for any zero value), translating each color.ColorValue
+// into the concrete "colors" payload the given LEDBackend expects.
+func generatePresetSequence(spec PresetSpec, backend color.LEDBackend) map[string]any {
+	animationType := spec.AnimationType
+	if animationType == "" {
+		animationType = "pulse"
+	}
+	speed := spec.Speed
+	if speed == 0 {
+		speed = 0.001
+	}
+	presetPeriod := spec.Period
+	if presetPeriod == 0 {
+		presetPeriod = period
+	}
+	presetDuration := spec.Duration
+	if presetDuration == 0 {
+		presetDuration = time.Duration(presetPeriod) * time.Second
+	}
+
+	sequences := make(map[string]any)
+	colors := spec.Colors
+	for i := 0; i < 3; i++ {
+		animations := make([]map[string]any, len(colors))
+		for j := 0; j < len(colors); j++ {
+			colorIndex := (i + j) % len(colors)
+			animations[j] = map[string]any{
+				"set_animation": animationType,
+				"speed":         speed,
+				"period":        presetPeriod,
+				"colors":        []any{backend.ColorCommand(colors[colorIndex])},
+			}
+		}
+		sequences[fmt.Sprintf("%d", i)] = map[string]any{
+			"sequence": map[string]any{
+				"animations": animations,
+				"duration":   presetDuration.Seconds(),
+			},
+		}
+	}
+	return sequences
+}
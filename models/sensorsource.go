@@ -0,0 +1,168 @@
+package models
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+)
+
+// Reading is a single result from a sensorSource: either a fresh set of
+// sensor values, or the error encountered trying to get one.
+type Reading struct {
+	Data map[string]any
+	Err  error
+}
+
+// sensorSource decouples "how we get weather data" from "what we do with
+// it", so a flaky network-backed sensor can be retried with backoff without
+// touching visualizeWeather, and a sensor that already streams can push
+// readings without being polled at all.
+type sensorSource interface {
+	// Subscribe starts producing readings and returns a channel of them.
+	// The channel is closed once ctx is done.
+	Subscribe(ctx context.Context) <-chan Reading
+}
+
+const maxBackoff = 15 * time.Minute
+
+// backoffState tracks the current retry delay for consecutive sensor read
+// errors: it starts at base, doubles on every failure up to cap, and resets
+// to base as soon as a read succeeds. Safe for concurrent reads via
+// Current() while the poll loop advances it.
+type backoffState struct {
+	mu      sync.Mutex
+	base    time.Duration
+	cap     time.Duration
+	current time.Duration
+}
+
+func newBackoffState(base time.Duration) *backoffState {
+	return &backoffState{base: base, cap: maxBackoff, current: base}
+}
+
+// next returns the delay to wait before the next attempt, advancing the
+// backoff on failure and resetting it on success.
+func (b *backoffState) next(success bool) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.current = b.base
+		return b.current
+	}
+	delay := b.current
+	b.current *= 2
+	if b.current > b.cap {
+		b.current = b.cap
+	}
+	return withJitter(delay)
+}
+
+// Current returns the present backoff delay without advancing it.
+func (b *backoffState) Current() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.current
+}
+
+// withJitter randomizes a delay by up to +/-20% so many instances backing
+// off at once don't all retry in lockstep.
+func withJitter(d time.Duration) time.Duration {
+	jitter := time.Duration(float64(d) * 0.2 * (rand.Float64()*2 - 1))
+	return d + jitter
+}
+
+// pollingSource wraps a viam sensor.Sensor, reading it on refreshInterval
+// and backing off exponentially (capped at 15 minutes, reset on success)
+// whenever consecutive reads fail.
+type pollingSource struct {
+	sensor          sensor.Sensor
+	refreshInterval time.Duration
+	logger          logging.Logger
+	clock           clock.Clock
+	backoff         *backoffState
+}
+
+func newPollingSource(s sensor.Sensor, refreshInterval time.Duration, logger logging.Logger) *pollingSource {
+	return &pollingSource{
+		sensor:          s,
+		refreshInterval: refreshInterval,
+		logger:          logger,
+		clock:           clock.New(),
+		backoff:         newBackoffState(refreshInterval),
+	}
+}
+
+func (p *pollingSource) Subscribe(ctx context.Context) <-chan Reading {
+	ch := make(chan Reading)
+	go func() {
+		defer close(ch)
+		backoff := p.backoff
+		for {
+			data, err := p.sensor.Readings(ctx, nil)
+			select {
+			case ch <- Reading{Data: data, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+
+			delay := backoff.next(err == nil)
+			t := p.clock.Timer(delay)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return
+			case <-t.C:
+			}
+		}
+	}()
+	return ch
+}
+
+// streamingSensor is an optional capability a sensor.Sensor dependency can
+// implement to push readings as they arrive instead of being polled on
+// refreshInterval. Reconfigure type-asserts the configured weather sensor
+// against this interface and prefers it over pollingSource when present.
+type streamingSensor interface {
+	sensor.Sensor
+	SubscribeReadings(ctx context.Context) (<-chan Reading, error)
+}
+
+// pushSource adapts a sensor that already streams readings (rather than
+// needing to be polled) to the sensorSource interface: it simply forwards
+// whatever arrives on upstream until ctx is done.
+type pushSource struct {
+	upstream <-chan Reading
+}
+
+func newPushSource(upstream <-chan Reading) *pushSource {
+	return &pushSource{upstream: upstream}
+}
+
+func (p *pushSource) Subscribe(ctx context.Context) <-chan Reading {
+	ch := make(chan Reading)
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case reading, ok := <-p.upstream:
+				if !ok {
+					return
+				}
+				select {
+				case ch <- reading:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+// fakeSensor is a minimal sensor.Sensor whose Readings delegate is supplied
+// per test.
+type fakeSensor struct {
+	readings func() (map[string]any, error)
+}
+
+func (f *fakeSensor) Name() resource.Name { return resource.Name{} }
+func (f *fakeSensor) DoCommand(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	return map[string]any{}, nil
+}
+func (f *fakeSensor) Close(ctx context.Context) error { return nil }
+func (f *fakeSensor) Readings(ctx context.Context, extra map[string]any) (map[string]any, error) {
+	return f.readings()
+}
+
+func TestPollingSourceTicksOnInjectedClock(t *testing.T) {
+	mock := clock.NewMock()
+	calls := 0
+	sensor := &fakeSensor{readings: func() (map[string]any, error) {
+		calls++
+		return map[string]any{"n": calls}, nil
+	}}
+
+	src := newPollingSource(sensor, time.Second, logging.NewTestLogger(t))
+	src.clock = mock
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := src.Subscribe(ctx)
+
+	first := <-ch
+	if first.Err != nil || first.Data["n"] != 1 {
+		t.Fatalf("first reading = %+v, want n=1", first)
+	}
+
+	// Advance the mock clock until the second tick's reading arrives; the
+	// poll goroutine may not have armed its timer the instant Subscribe
+	// returns, so retry advancing until it has.
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case second := <-ch:
+			if second.Err != nil || second.Data["n"] != 2 {
+				t.Fatalf("second reading = %+v, want n=2", second)
+			}
+			return
+		case <-deadline:
+			t.Fatal("timed out waiting for second poll tick")
+		default:
+			mock.Add(time.Second)
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestBackoffStateDoublesAndResets(t *testing.T) {
+	b := newBackoffState(time.Second)
+
+	if got := b.Current(); got != time.Second {
+		t.Fatalf("initial backoff = %v, want %v", got, time.Second)
+	}
+
+	// Consecutive failures double the delay (ignoring jitter) up to the cap.
+	b.next(false)
+	if got := b.Current(); got != 2*time.Second {
+		t.Fatalf("backoff after 1 failure = %v, want %v", got, 2*time.Second)
+	}
+	b.next(false)
+	if got := b.Current(); got != 4*time.Second {
+		t.Fatalf("backoff after 2 failures = %v, want %v", got, 4*time.Second)
+	}
+
+	// A success resets it back to base.
+	b.next(true)
+	if got := b.Current(); got != time.Second {
+		t.Fatalf("backoff after success = %v, want %v", got, time.Second)
+	}
+}
+
+func TestBackoffStateCapsAtMax(t *testing.T) {
+	b := newBackoffState(time.Minute)
+	for i := 0; i < 10; i++ {
+		b.next(false)
+	}
+	if got := b.Current(); got != maxBackoff {
+		t.Fatalf("backoff after many failures = %v, want cap %v", got, maxBackoff)
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+// fakeLED is a minimal resource.Resource standing in for a bound LED
+// component; it records nothing and always succeeds.
+type fakeLED struct{}
+
+func (f *fakeLED) Name() resource.Name { return resource.Name{} }
+func (f *fakeLED) DoCommand(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	return map[string]any{}, nil
+}
+func (f *fakeLED) Close(ctx context.Context) error { return nil }
+
+func newTestService(t *testing.T) *weatherboxServiceService {
+	return &weatherboxServiceService{
+		logger:             logging.NewTestLogger(t),
+		events:             newEventBus(),
+		resolvedAnimations: defaultAnimations,
+		ledComponents: []boundLED{
+			{name: "led1", component: &fakeLED{}, binding: LEDBinding{Name: "led1"}},
+		},
+	}
+}
+
+func TestVisualizeWeatherDedupsConditionChanged(t *testing.T) {
+	s := newTestService(t)
+	subID := s.events.Join(8, []string{string(EventConditionChanged)})
+
+	reading := Reading{Data: map[string]any{"code": float64(1000), "outside_f": float64(70)}}
+
+	ctx := context.Background()
+	s.visualizeWeather(ctx, reading)
+	s.visualizeWeather(ctx, reading)
+	s.visualizeWeather(ctx, reading)
+
+	evts, ok := s.events.Poll(subID)
+	if !ok {
+		t.Fatal("expected subscription to exist")
+	}
+	if len(evts) != 1 {
+		t.Fatalf("expected exactly 1 condition_changed event for 3 identical readings, got %d", len(evts))
+	}
+}
+
+func TestVisualizeWeatherEmitsOnTransition(t *testing.T) {
+	s := newTestService(t)
+	subID := s.events.Join(8, []string{string(EventConditionChanged)})
+
+	ctx := context.Background()
+	s.visualizeWeather(ctx, Reading{Data: map[string]any{"code": float64(1000), "outside_f": float64(70)}})
+	s.visualizeWeather(ctx, Reading{Data: map[string]any{"code": float64(1006), "outside_f": float64(70)}})
+
+	evts, ok := s.events.Poll(subID)
+	if !ok {
+		t.Fatal("expected subscription to exist")
+	}
+	if len(evts) != 2 {
+		t.Fatalf("expected 2 condition_changed events across 2 distinct readings, got %d", len(evts))
+	}
+}
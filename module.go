@@ -1,3 +1,12 @@
+// Package weatherviz is the original, pre-config-driven weatherbox model:
+// a single configured LED component, a single configured weather sensor, and a single
+// hardcoded animation ("sunny/hot") applied on every DoCommand({"visualize": true}).
+// It predates the configurable animation-presets/condition-map, multi-LED
+// fan-out, and per-binding color-space support that models.Service grew
+// afterward, and those features have intentionally not been backported here.
+// New weatherbox deployments should use the "service" model in the models
+// package instead; this model is kept only for existing configs still
+// pointed at "vijayvuyyuru:weatherviz:weatherviz".
 package weatherviz
 
 import (
@@ -11,6 +20,8 @@ import (
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/services/generic"
+
+	"weatherbox-service/color"
 )
 
 const (
@@ -22,27 +33,30 @@ const (
 var (
 	Weatherviz       = resource.NewModel("vijayvuyyuru", "weatherviz", "weatherviz")
 	errUnimplemented = errors.New("unimplemented")
-	orange           = []any{255, 70, 0}
-	red              = []any{255, 0, 120}
-	magenta          = []any{255, 0, 5}
-
-	purple       = []any{150, 0, 255}
-	blue         = []any{0, 0, 255}
-	green        = []any{50, 250, 10}
-	cyan         = []any{69, 255, 226}
-	white        = []any{255, 255, 255}
+	orange           = color.RGB(255, 70, 0)
+	red              = color.RGB(255, 0, 120)
+	magenta          = color.RGB(255, 0, 5)
+
+	purple       = color.RGB(150, 0, 255)
+	blue         = color.RGB(0, 0, 255)
+	green        = color.RGB(50, 250, 10)
+	cyan         = color.RGB(69, 255, 226)
+	white        = color.RGB(255, 255, 255)
 	animationMap = map[string]map[string]any{
-		"sunny/hot":   generateSequence([][]any{orange, red, magenta}),
-		"sunny/cold":  generateSequence([][]any{orange, purple, blue}),
-		"cloudy/hot":  generateSequence([][]any{white, magenta, red}),
-		"cloudy/cold": generateSequence([][]any{white, purple, blue}),
-		"rainy/hot":   generateSequence([][]any{cyan, magenta, red}),
-		"none":        generateSequence([][]any{green, magenta, red}),
-		"all":         generateSequence([][]any{magenta, purple, orange}),
+		"sunny/hot":   generateSequence([]color.ColorValue{orange, red, magenta}, color.RGBBackend{}),
+		"sunny/cold":  generateSequence([]color.ColorValue{orange, purple, blue}, color.RGBBackend{}),
+		"cloudy/hot":  generateSequence([]color.ColorValue{white, magenta, red}, color.RGBBackend{}),
+		"cloudy/cold": generateSequence([]color.ColorValue{white, purple, blue}, color.RGBBackend{}),
+		"rainy/hot":   generateSequence([]color.ColorValue{cyan, magenta, red}, color.RGBBackend{}),
+		"none":        generateSequence([]color.ColorValue{green, magenta, red}, color.RGBBackend{}),
+		"all":         generateSequence([]color.ColorValue{magenta, purple, orange}, color.RGBBackend{}),
 	}
 )
 
-func generateSequence(colors [][]any) map[string]any {
+// generateSequence builds the three rotated animation sequences for a
+// palette, translating each color.ColorValue into the concrete "colors"
+// payload the given LEDBackend expects.
+func generateSequence(colors []color.ColorValue, backend color.LEDBackend) map[string]any {
 	sequences := make(map[string]any)
 
 	// Generate three sequences with different starting points
@@ -56,7 +70,7 @@ func generateSequence(colors [][]any) map[string]any {
 				"set_animation": "pulse",
 				"speed":         0.001,
 				"period":        period,
-				"colors":        []any{colors[colorIndex]},
+				"colors":        []any{backend.ColorCommand(colors[colorIndex])},
 			}
 		}
 